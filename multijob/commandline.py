@@ -320,15 +320,97 @@ def _string_from_value(name, value, coercion):
 
     return coercion(name, value)
 
-def _unparsed_dict_from_argv(argv):
+def _unparsed_dict_from_argv(argv, *,
+                             kv_separator='=',
+                             strict_duplicates=True,
+                             bare_flags=False,
+                             repeated_names=frozenset(),
+                             positional_name=None):
     """Split command line arguments into a dict, without applying coercions.
+
+    Example::
+
+        >>> _unparsed_dict_from_argv(['a=1', 'b=x=y'])
+        {'a': '1', 'b': 'x=y'}
+        >>> _unparsed_dict_from_argv(['a:1', 'b:x:y'], kv_separator=':')
+        {'a': '1', 'b': 'x:y'}
+
+    Example: each argument must contain the kv_separator::
+
+        >>> _unparsed_dict_from_argv(['x=1'], kv_separator=':')
+        Traceback (most recent call last):
+        ValueError: can't split 'x=1' at ':'
+
+    Example: with bare_flags, arguments without kv_separator are 'True'::
+
+        >>> _unparsed_dict_from_argv(['a=1', 'verbose'], bare_flags=True)
+        {'a': '1', 'verbose': 'True'}
+
+    Example: bare flags must have a name::
+
+        >>> _unparsed_dict_from_argv([''], bare_flags=True)
+        Traceback (most recent call last):
+        ValueError: can't split '' at '='
+
+    Example: names must be unique::
+
+        >>> _unparsed_dict_from_argv(['a=1', 'b=2', 'a=3'])
+        Traceback (most recent call last):
+        ValueError: duplicate argument 'a': '1' and '3'
+
+    Example: without strict_duplicates, the last value wins::
+
+        >>> _unparsed_dict_from_argv(['a=1', 'a=3'], strict_duplicates=False)
+        {'a': '3'}
+
+    Example: repeated_names collect all values into a list::
+
+        >>> _unparsed_dict_from_argv(['s=1', 'a=2', 's=3'],
+        ...                          repeated_names={'s'})
+        {'s': ['1', '3'], 'a': '2'}
+
+    Example: positional_name collects arguments without kv_separator::
+
+        >>> _unparsed_dict_from_argv(['a=1', 'in.txt', 'out.txt'],
+        ...                          positional_name='files')
+        {'a': '1', 'files': ['in.txt', 'out.txt']}
+        >>> _unparsed_dict_from_argv(['a=1'], positional_name='files')
+        {'a': '1', 'files': []}
+
+    Example: the positional_name can't be used as a named argument::
+
+        >>> _unparsed_dict_from_argv(['files=x'], positional_name='files')
+        Traceback (most recent call last):
+        ValueError: positional param 'files' can't be given as named argument
     """
     arg_dict = dict()
 
     for arg in argv:
-        name, value = arg.split('=', 1)
+        if kv_separator in arg:
+            name, value = arg.split(kv_separator, 1)
+            if name == positional_name:
+                raise ValueError(
+                    "positional param {!r} can't be given as named argument"
+                    .format(name))
+        elif bare_flags and arg:
+            name, value = arg, 'True'
+        elif positional_name is not None and arg:
+            arg_dict.setdefault(positional_name, []).append(arg)
+            continue
+        else:
+            raise ValueError(
+                "can't split {!r} at {!r}".format(arg, kv_separator))
+        if name in repeated_names:
+            arg_dict.setdefault(name, []).append(value)
+            continue
+        if strict_duplicates and name in arg_dict:
+            raise ValueError("duplicate argument {!r}: {!r} and {!r}".format(
+                name, arg_dict[name], value))
         arg_dict[name] = value
 
+    if positional_name is not None:
+        arg_dict.setdefault(positional_name, [])
+
     return arg_dict
 
 class UnparsedArguments(object):
@@ -339,18 +421,49 @@ class UnparsedArguments(object):
     Use :meth:`from_argv` to construct this object from an argv array.
 
     Args:
-        args (dict): the name-value command line parameters.
+        args (dict):
+            the name-value command line parameters.
+            A value may be a list of strings for repeated names.
     """
 
     def __init__(self, args):
         self._args = dict(args)
 
     @staticmethod
-    def from_argv(argv):
+    def from_argv(argv, *,
+                  kv_separator='=', strict_duplicates=True, bare_flags=False,
+                  repeated_names=frozenset(), positional_name=None):
         """Create UnparsedArguments from an argv array.
+
+        Args:
+            argv (list): The ``name=value`` arguments.
+            kv_separator (str): Optional. Separates names from values.
+            strict_duplicates (bool):
+                Optional. Reject repeated names instead of keeping the last
+                value. Defaults to *True*.
+            bare_flags (bool):
+                Optional. Treat arguments without kv_separator as the value
+                ``'True'`` instead of rejecting them. Defaults to *False*.
+            repeated_names (set):
+                Optional. Names whose values are all collected into a list,
+                regardless of strict_duplicates.
+            positional_name (str):
+                Optional. Collect arguments without kv_separator into a list
+                under this name, instead of rejecting them.
+
+        Raises:
+            ValueError: when an argument can't be split at the kv_separator
+                and neither bare_flags nor positional_name applies,
+                or when a name occurs more than once in strict mode.
         """
 
-        return UnparsedArguments(_unparsed_dict_from_argv(argv))
+        return UnparsedArguments(_unparsed_dict_from_argv(
+            argv,
+            kv_separator=kv_separator,
+            strict_duplicates=strict_duplicates,
+            bare_flags=bare_flags,
+            repeated_names=repeated_names,
+            positional_name=positional_name))
 
     def read(self, name, coercion):
         """Consume and coerce a named argument.
@@ -363,6 +476,7 @@ class UnparsedArguments(object):
 
         Returns:
             the coerced value.
+            For a repeated name, a list with each value coerced.
 
         Raises:
             KeyError: when no such argument name exists.
@@ -374,6 +488,9 @@ class UnparsedArguments(object):
         except KeyError:
             raise KeyError("expected {!r} in argv".format(name))
 
+        if isinstance(value, list):
+            return [value_from_string(name, item, coercion) for item in value]
+
         return value_from_string(name, value, coercion)
 
     def __bool__(self):
@@ -383,7 +500,12 @@ class UnparsedArguments(object):
         return iter(self._args)
 
 
-def _dict_from_argv(argv, *, typemap, default_coercion=None):
+def _dict_from_argv(argv, *, typemap, default_coercion=None,
+                    kv_separator='=',
+                    strict_duplicates=True,
+                    bare_flags=False,
+                    repeated_names=frozenset(),
+                    positional_name=None):
     """Parse command line args to a dict.
 
     Example::
@@ -403,7 +525,13 @@ def _dict_from_argv(argv, *, typemap, default_coercion=None):
 
     arg_dict = dict()
 
-    unparsed = UnparsedArguments.from_argv(argv)
+    unparsed = UnparsedArguments.from_argv(
+        argv,
+        kv_separator=kv_separator,
+        strict_duplicates=strict_duplicates,
+        bare_flags=bare_flags,
+        repeated_names=repeated_names,
+        positional_name=positional_name)
     for name in list(unparsed):
         coercion = typemap.get(name, default_coercion)
         value = unparsed.read(name, coercion)
@@ -411,7 +539,9 @@ def _dict_from_argv(argv, *, typemap, default_coercion=None):
 
     return arg_dict
 
-def _argv_from_dict(arg_dict, *, typemap=None, default_coercion=None):
+def _argv_from_dict(arg_dict, *,
+                    typemap=None, default_coercion=None, kv_separator='=',
+                    repeated_names=frozenset()):
     """Format a dict as command line args.
 
     Example::
@@ -419,6 +549,17 @@ def _argv_from_dict(arg_dict, *, typemap=None, default_coercion=None):
         >>> argd = dict(a=42, b=True, c='foo=bar', d='42')
         >>> _argv_from_dict(argd)
         ['a=42', 'b=True', 'c=foo=bar', 'd=42']
+
+    Example: names must not contain the kv_separator::
+
+        >>> _argv_from_dict({'opt:lr': 0.1}, kv_separator=':')
+        Traceback (most recent call last):
+        ValueError: name 'opt:lr' must not contain kv_separator ':'
+
+    Example: repeated_names emit one argument per item::
+
+        >>> _argv_from_dict(dict(a=1, s=[1, 2]), repeated_names={'s'})
+        ['a=1', 's=1', 's=2']
     """
 
     if typemap is None:
@@ -427,10 +568,47 @@ def _argv_from_dict(arg_dict, *, typemap=None, default_coercion=None):
     argv = []
 
     for name in sorted(arg_dict):
-        value = arg_dict[name]
+        if kv_separator in name:
+            raise ValueError("name {!r} must not contain kv_separator {!r}"
+                             .format(name, kv_separator))
+        values = arg_dict[name]
+        if name not in repeated_names:
+            values = [values]
         coercion = typemap.get(name, default_coercion)
-        coerced_value = _string_from_value(name, value, coercion)
-        argv.append("{}={}".format(name, coerced_value))
+        for value in values:
+            coerced_value = _string_from_value(name, value, coercion)
+            argv.append("{}{}{}".format(name, kv_separator, coerced_value))
+
+    return argv
+
+def _positional_argv_from_list(name, values, *,
+                               coercion, kv_separator, separator):
+    """Format positional values as command line args.
+
+    Example::
+
+        >>> _positional_argv_from_list('files', ['a.txt', 'b.txt'],
+        ...                            coercion=None,
+        ...                            kv_separator='=', separator='--')
+        ['a.txt', 'b.txt']
+
+    Example: values must not look like other arguments::
+
+        >>> _positional_argv_from_list('files', ['x=1'],
+        ...                            coercion=None,
+        ...                            kv_separator='=', separator='--')
+        Traceback (most recent call last):
+        ValueError: positional param 'files' can't encode 'x=1'
+    """
+
+    argv = []
+
+    for value in values:
+        word = _string_from_value(name, value, coercion)
+        if not word or kv_separator in word or word == separator:
+            raise ValueError("positional param {!r} can't encode {!r}"
+                             .format(name, word))
+        argv.append(word)
 
     return argv
 
@@ -440,13 +618,195 @@ class JobArgvConfig(object):
     Attributes:
         job_id_key (str): Name of the ``job_id`` attribute.
         repetition_id_key (str): Name of the ``repetition_id`` attribute.
+        kv_separator (str):
+            Separates names from values, defaults to ``'='``.
+            Must be a single character that does not occur in the separator.
+        separator (str):
+            Separates the job attributes from the params, defaults to
+            ``'--'``. Use an alternative like ``'++'`` if a launcher
+            swallows ``'--'``.
+        strict_duplicates (bool):
+            Reject arguments whose name occurs more than once.
+            Defaults to *True*. Set to *False* to let the last value win,
+            e.g. to override a param by appending it again.
+        bare_flags (bool):
+            Accept params without a value, such as ``verbose``, as
+            ``verbose=True``. This suits the named ``'bool'`` coercion.
+            Defaults to *False*, so that a token without kv_separator is
+            reported as an error. Only applies to params, not to the job
+            attributes before the separator.
+        repeated_params (frozenset):
+            Names of params that may be given several times,
+            e.g. ``seed=1 seed=2 seed=3``.
+            Their values are coerced one by one and collected into a list,
+            and :func:`argv_from_job` emits one argument per list item.
+            An empty list therefore round-trips to an absent param.
+            Defaults to no names.
+        positional_param (str):
+            Name of a param that collects all params without kv_separator,
+            e.g. trailing input files.
+            The param is always present as a list, possibly empty,
+            and :func:`argv_from_job` emits its items after the named params.
+            Can't be combined with bare_flags. Defaults to *None*.
+
+    The configuration is checked with :meth:`validate` on construction.
     """
 
     # pylint: disable=too-few-public-methods
 
-    def __init__(self, *, job_id_key, repetition_id_key):
+    def __init__(self, *, job_id_key, repetition_id_key,
+                 kv_separator='=', separator='--', strict_duplicates=True,
+                 bare_flags=False, repeated_params=(), positional_param=None):
         self.job_id_key = job_id_key
         self.repetition_id_key = repetition_id_key
+        self.kv_separator = kv_separator
+        self.separator = separator
+        self.strict_duplicates = strict_duplicates
+        self.bare_flags = bare_flags
+        self.repeated_params = frozenset(repeated_params)
+        self.positional_param = positional_param
+        self.validate()
+
+    def validate(self):
+        """Check that this configuration can encode jobs unambiguously.
+
+        :func:`argv_from_job` and :func:`job_from_argv` call this again,
+        since the attributes may have been changed after construction.
+
+        Raises:
+            ValueError: when the configuration is invalid.
+
+        Example: all fields must be strings::
+
+            >>> JobArgvConfig(job_id_key=None, repetition_id_key='--rep')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: job_id_key must be a str: None
+
+        Example: switches must be bools::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--rep',
+            ...               bare_flags='no')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: bare_flags must be a bool: 'no'
+
+        Example: repeated_params must be names::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--rep',
+            ...               repeated_params=['seed', 42])
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: repeated_params must only contain str: 42
+
+        Example: bare_flags and positional_param are mutually exclusive::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--rep',
+            ...               bare_flags=True, positional_param='files')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: bare_flags and positional_param can't both be set
+
+        Example: the id keys must be present::
+
+            >>> JobArgvConfig(job_id_key='', repetition_id_key='--rep')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: job_id_key must not be empty
+
+        Example: the id keys must be distinct::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--id')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: job_id_key and repetition_id_key are both '--id'
+
+        Example: kv_separator must be a single character::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--rep',
+            ...               kv_separator='::')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: kv_separator must be a single character: '::'
+
+        Example: the separator must be present::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--rep',
+            ...               separator='')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: separator must not be empty
+
+        Example: kv_separator must not collide with the separator::
+
+            >>> JobArgvConfig(job_id_key='--id', repetition_id_key='--rep',
+            ...               kv_separator='-')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: kv_separator must not occur in separator '--': '-'
+
+        Example: the id keys must not contain the kv_separator::
+
+            >>> JobArgvConfig(job_id_key='--job:id', repetition_id_key='--rep',
+            ...               kv_separator=':')
+            Traceback (most recent call last):
+            ValueError: invalid JobArgvConfig: job_id_key must not contain kv_separator ':': '--job:id'
+        """
+
+        def _fail(message, *args):
+            raise ValueError(
+                "invalid JobArgvConfig: " + message.format(*args))
+
+        for attr in ('job_id_key', 'repetition_id_key',
+                     'kv_separator', 'separator'):
+            value = getattr(self, attr)
+            if not isinstance(value, str):
+                _fail("{} must be a str: {!r}", attr, value)
+
+        for attr in ('strict_duplicates', 'bare_flags'):
+            value = getattr(self, attr)
+            if not isinstance(value, bool):
+                _fail("{} must be a bool: {!r}", attr, value)
+
+        if not isinstance(self.repeated_params, frozenset):
+            _fail("repeated_params must be a frozenset: {!r}",
+                  self.repeated_params)
+
+        for name in sorted(self.repeated_params, key=repr):
+            if not isinstance(name, str):
+                _fail("repeated_params must only contain str: {!r}", name)
+
+        kv_separator = self.kv_separator
+        separator = self.separator
+
+        if not separator:
+            _fail("separator must not be empty")
+
+        if len(kv_separator) != 1:
+            _fail("kv_separator must be a single character: {!r}",
+                  kv_separator)
+
+        if kv_separator in separator:
+            _fail("kv_separator must not occur in separator {!r}: {!r}",
+                  separator, kv_separator)
+
+        for attr in ('job_id_key', 'repetition_id_key'):
+            key = getattr(self, attr)
+            if not key:
+                _fail("{} must not be empty", attr)
+            if kv_separator in key:
+                _fail("{} must not contain kv_separator {!r}: {!r}",
+                      attr, kv_separator, key)
+
+        if self.job_id_key == self.repetition_id_key:
+            _fail("job_id_key and repetition_id_key are both {!r}",
+                  self.job_id_key)
+
+        positional_param = self.positional_param
+
+        if positional_param is not None:
+            if not isinstance(positional_param, str) or not positional_param:
+                _fail("positional_param must be None or a non-empty str: {!r}",
+                      positional_param)
+            if kv_separator in positional_param:
+                _fail("positional_param must not contain kv_separator {!r}: "
+                      "{!r}", kv_separator, positional_param)
+            if self.bare_flags:
+                _fail("bare_flags and positional_param can't both be set")
+            if positional_param in self.repeated_params:
+                _fail("positional_param {!r} must not be in repeated_params",
+                      positional_param)
 
 DEFAULT_JOB_ARGV_CONFIG = JobArgvConfig(
     job_id_key='--id',
@@ -473,11 +833,17 @@ def argv_from_job(job,
         default_coercion (Coercion):
             Optional. Controls how params without a typemap entry are formatted.
         job_argv_config (JobArgvConfig):
-            Optional. Controls names of job attributes.
+            Optional. Controls names of job attributes, the separators,
+            duplicate handling, bare flags, repeated and positional params.
+            Defaults to ``DEFAULT_JOB_ARGV_CONFIG``.
 
     Returns:
         list: The encoded params.
 
+    Raises:
+        ValueError: when the job_argv_config is invalid,
+            or when a param name contains the kv_separator.
+
     Example: simple usage::
 
         >>> from multijob.job import Job
@@ -511,24 +877,88 @@ def argv_from_job(job,
         >>> argv_from_job(job, job_argv_config=conf)
         ['--job-id=2', '--nexecs=7', '--', 'a=b']
 
+    Example: customizing the name/value separator::
+
+        >>> from multijob.job import Job
+        >>> conf = JobArgvConfig(
+        ...     job_id_key='--id',
+        ...     repetition_id_key='--rep',
+        ...     kv_separator=':')
+        >>> job = Job(2, 7, lambda x: x, dict(x=1))
+        >>> argv_from_job(job, job_argv_config=conf)
+        ['--id:2', '--rep:7', '--', 'x:1']
+
+    Example: customizing the separator::
+
+        >>> from multijob.job import Job
+        >>> conf = JobArgvConfig(
+        ...     job_id_key='--id',
+        ...     repetition_id_key='--rep',
+        ...     separator='++')
+        >>> job = Job(2, 7, lambda x: x, dict(x=1))
+        >>> argv_from_job(job, job_argv_config=conf)
+        ['--id=2', '--rep=7', '++', 'x=1']
+
+    Example: repeated params::
+
+        >>> from multijob.job import Job
+        >>> conf = JobArgvConfig(
+        ...     job_id_key='--id',
+        ...     repetition_id_key='--rep',
+        ...     repeated_params={'seed'})
+        >>> job = Job(2, 7, lambda seed: seed, dict(seed=[1, 2, 3]))
+        >>> argv_from_job(job, job_argv_config=conf)
+        ['--id=2', '--rep=7', '--', 'seed=1', 'seed=2', 'seed=3']
+
+    Example: positional params::
+
+        >>> from multijob.job import Job
+        >>> conf = JobArgvConfig(
+        ...     job_id_key='--id',
+        ...     repetition_id_key='--rep',
+        ...     positional_param='files')
+        >>> job = Job(2, 7, lambda n, files: n, dict(n=3, files=['a', 'b']))
+        >>> argv_from_job(job, job_argv_config=conf)
+        ['--id=2', '--rep=7', '--', 'n=3', 'a', 'b']
+
     """
 
     if job_argv_config is None:
         job_argv_config = DEFAULT_JOB_ARGV_CONFIG
 
+    job_argv_config.validate()
+
+    kv_separator = job_argv_config.kv_separator
+
     meta = _argv_from_dict({
         job_argv_config.job_id_key: job.job_id,
         job_argv_config.repetition_id_key: job.repetition_id,
-    })
+    }, kv_separator=kv_separator)
+
+    named_params = dict(job.params)
+    positional_param = job_argv_config.positional_param
+    positional_values = []
+    if positional_param is not None:
+        positional_values = named_params.pop(positional_param, [])
 
-    params = _argv_from_dict(job.params,
+    params = _argv_from_dict(named_params,
                              typemap=typemap,
-                             default_coercion=default_coercion)
+                             default_coercion=default_coercion,
+                             kv_separator=kv_separator,
+                             repeated_names=job_argv_config.repeated_params)
+
+    positionals = _positional_argv_from_list(
+        positional_param,
+        positional_values,
+        coercion=(typemap or {}).get(positional_param, default_coercion),
+        kv_separator=kv_separator,
+        separator=job_argv_config.separator)
 
     argv = []
     argv.extend(meta)
-    argv.append('--')
+    argv.append(job_argv_config.separator)
     argv.extend(params)
+    argv.extend(positionals)
 
     return argv
 
@@ -551,11 +981,23 @@ def job_from_argv(argv,
         default_coercion (Coercion):
             Optional. Controls how params without a typemap entry are formatted.
         job_argv_config (JobArgvConfig):
-            Optional. Controls names of job attributes.
+            Optional. Controls names of job attributes, the separators,
+            duplicate handling, bare flags, repeated and positional params.
+            Defaults to ``DEFAULT_JOB_ARGV_CONFIG``.
 
     Returns:
         multijob.job.Job: a runnable job with the params from this argv.
 
+    Raises:
+        ValueError:
+            when the job_argv_config is invalid,
+            when the separator is missing,
+            when an argument can't be split at the kv_separator,
+            when a name occurs more than once with strict_duplicates,
+            or when a value can't be coerced.
+        KeyError: when the job id or repetition id is missing.
+        TypeError: when unexpected job attributes are present.
+
     Example: simple usage::
 
         >>> def target(a, b, c):
@@ -602,15 +1044,30 @@ def job_from_argv(argv,
     if job_argv_config is None:
         job_argv_config = DEFAULT_JOB_ARGV_CONFIG
 
+    job_argv_config.validate()
+
+    separator = job_argv_config.separator
+
     try:
-        separator_ix = argv.index('--')
+        separator_ix = argv.index(separator)
     except ValueError:
-        raise ValueError("no argument separator '--' found")
+        raise ValueError(
+            "no argument separator {!r} found".format(separator))
 
     meta_args = argv[:separator_ix]
     param_args = argv[separator_ix + 1:]
 
-    raw_meta = UnparsedArguments.from_argv(meta_args)
+    kv_separator = job_argv_config.kv_separator
+    strict_duplicates = job_argv_config.strict_duplicates
+
+    if separator in param_args:
+        raise ValueError(
+            "can't split {!r} at {!r}".format(separator, kv_separator))
+
+    raw_meta = UnparsedArguments.from_argv(
+        meta_args,
+        kv_separator=kv_separator,
+        strict_duplicates=strict_duplicates)
 
     job_id = raw_meta.read(job_argv_config.job_id_key, int)
     repetition_id = raw_meta.read(job_argv_config.repetition_id_key, int)
@@ -621,7 +1078,12 @@ def job_from_argv(argv,
 
     params = _dict_from_argv(param_args,
                              typemap=typemap,
-                             default_coercion=default_coercion)
+                             default_coercion=default_coercion,
+                             kv_separator=kv_separator,
+                             strict_duplicates=strict_duplicates,
+                             bare_flags=job_argv_config.bare_flags,
+                             repeated_names=job_argv_config.repeated_params,
+                             positional_name=job_argv_config.positional_param)
 
     return multijob.job.Job(job_id, repetition_id, callback, params)
 
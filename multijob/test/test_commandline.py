@@ -8,6 +8,7 @@ which are focussed on normal usage.
 
 import pytest
 import multijob.commandline as commandline
+import multijob.job
 
 def describe_job_from_argv():
 
@@ -42,3 +43,438 @@ def describe_job_from_argv():
 
         with pytest.raises(TypeError):
             commandline.job_from_argv(argv, target, typemap={})
+
+    def it_uses_equals_as_default_kv_separator():
+
+        def target(x):
+            return x
+
+        argv = ['--id=1', '--rep=0', '--', 'x=1:2']
+        typemap = dict(x='str')
+
+        job = commandline.job_from_argv(argv, target, typemap=typemap)
+
+        assert job.params == dict(x='1:2')
+
+    def it_treats_equals_as_data_under_custom_kv_separator():
+
+        def target(x):
+            return x
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            kv_separator=':')
+        argv = ['--id:1', '--rep:0', '--', 'x:a=b:c']
+        typemap = dict(x='str')
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=typemap,
+                                        job_argv_config=conf)
+
+        assert job.params == dict(x='a=b:c')
+
+    def it_validates_config_modified_after_construction():
+
+        def target():
+            pass
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep')
+        conf.repetition_id_key = '--id'
+        argv = ['--id=1', '--']
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target,
+                                      typemap={},
+                                      job_argv_config=conf)
+
+    def it_treats_default_separator_as_data_under_custom_separator():
+
+        def target(x):
+            return x
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            separator='++')
+        argv = ['--id=1', '--rep=0', '++', 'x=--']
+        typemap = dict(x='str')
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=typemap,
+                                        job_argv_config=conf)
+
+        assert job.params == dict(x='--')
+
+    def it_rejects_a_repeated_separator_among_params():
+
+        def target(x):
+            return x
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            separator='++')
+        argv = ['--id=1', '--rep=0', '++', 'x=1', '++']
+
+        with pytest.raises(ValueError) as excinfo:
+            commandline.job_from_argv(argv, target,
+                                      typemap=dict(x='int'),
+                                      job_argv_config=conf)
+
+        assert "can't split '++' at '='" in str(excinfo.value)
+
+    def it_requires_the_configured_separator():
+
+        def target(x):
+            return x
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            separator='++')
+        argv = ['--id=1', '--rep=0', '--', 'x=1']
+        typemap = dict(x='int')
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target,
+                                      typemap=typemap,
+                                      job_argv_config=conf)
+
+    def it_throws_on_duplicate_params():
+
+        def target(x):
+            return x
+
+        argv = ['--id=1', '--rep=0', '--', 'x=1', 'x=2']
+        typemap = dict(x='int')
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target, typemap=typemap)
+
+    def it_throws_on_duplicate_meta_args():
+
+        def target():
+            pass
+
+        argv = ['--id=1', '--rep=0', '--id=2', '--']
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target, typemap={})
+
+    def it_explains_arguments_without_kv_separator():
+
+        def target(x):
+            return x
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            kv_separator=':')
+        argv = ['--id:1', '--rep:0', '--', 'x=1']
+
+        with pytest.raises(ValueError) as excinfo:
+            commandline.job_from_argv(argv, target,
+                                      typemap=dict(x='int'),
+                                      job_argv_config=conf)
+
+        assert "can't split 'x=1' at ':'" in str(excinfo.value)
+
+def describe_argv_from_job():
+
+    def it_round_trips_with_custom_kv_separator():
+
+        def target(a, b):
+            return a, b
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            kv_separator=':')
+        typemap = dict(a='str', b='float')
+        job = multijob.job.Job(3, 1, target, dict(a='x:y', b=0.1))
+
+        argv = commandline.argv_from_job(job, job_argv_config=conf)
+        decoded = commandline.job_from_argv(argv, target,
+                                            typemap=typemap,
+                                            job_argv_config=conf)
+
+        assert (decoded.job_id, decoded.repetition_id) == (3, 1)
+        assert decoded.params == job.params
+
+    def it_rejects_names_containing_kv_separator():
+
+        def target():
+            pass
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            kv_separator=':')
+        job = multijob.job.Job(0, 0, target, {'opt:lr': 0.1})
+
+        with pytest.raises(ValueError):
+            commandline.argv_from_job(job, job_argv_config=conf)
+
+def describe_job_argv_config():
+
+    def it_rejects_non_string_kv_separator():
+        with pytest.raises(ValueError) as excinfo:
+            commandline.JobArgvConfig(
+                job_id_key='--id',
+                repetition_id_key='--rep',
+                kv_separator=None)
+
+        assert 'invalid JobArgvConfig' in str(excinfo.value)
+
+def describe_job_from_argv_without_strict_duplicates():
+
+    def it_lets_the_last_value_win():
+
+        def target(a):
+            return a
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            strict_duplicates=False)
+        argv = ['--id=1', '--rep=0', '--', 'a=1', 'a=2']
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=dict(a='int'),
+                                        job_argv_config=conf)
+
+        assert job.params == dict(a=2)
+
+def describe_job_from_argv_with_bare_flags():
+
+    def it_reads_bare_params_as_true():
+
+        def target(verbose, n):
+            return verbose, n
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            bare_flags=True)
+        argv = ['--id=1', '--rep=0', '--', 'verbose', 'n=3']
+        typemap = dict(verbose='bool', n='int')
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=typemap,
+                                        job_argv_config=conf)
+
+        assert job.params == dict(verbose=True, n=3)
+
+    def it_still_requires_values_for_meta_args():
+
+        def target():
+            pass
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            bare_flags=True)
+        argv = ['--id=1', '--rep=0', '--dry-run', '--']
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target,
+                                      typemap={},
+                                      job_argv_config=conf)
+
+    def it_rejects_bare_params_by_default():
+
+        def target(verbose):
+            return verbose
+
+        argv = ['--id=1', '--rep=0', '--', 'verbose']
+
+        with pytest.raises(ValueError) as excinfo:
+            commandline.job_from_argv(argv, target,
+                                      typemap=dict(verbose='bool'))
+
+        assert "can't split 'verbose' at '='" in str(excinfo.value)
+
+    def it_rejects_empty_bare_params():
+
+        def target():
+            pass
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            bare_flags=True)
+        argv = ['--id=1', '--rep=0', '--', '']
+
+        with pytest.raises(ValueError) as excinfo:
+            commandline.job_from_argv(argv, target,
+                                      typemap={},
+                                      default_coercion='str',
+                                      job_argv_config=conf)
+
+        assert "can't split '' at '='" in str(excinfo.value)
+
+    def it_rejects_a_second_separator_as_bare_param():
+
+        def target():
+            pass
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            bare_flags=True)
+        argv = ['--id=1', '--rep=0', '--', 'verbose', '--']
+
+        with pytest.raises(ValueError) as excinfo:
+            commandline.job_from_argv(argv, target,
+                                      typemap={},
+                                      default_coercion='str',
+                                      job_argv_config=conf)
+
+        assert "can't split '--' at '='" in str(excinfo.value)
+
+def describe_job_from_argv_with_repeated_params():
+
+    def it_collects_all_values():
+
+        def target(seed, n):
+            return seed, n
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            repeated_params={'seed'})
+        argv = ['--id=1', '--rep=0', '--', 'seed=1', 'n=5', 'seed=2', 'seed=3']
+        typemap = dict(seed='int', n='int')
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=typemap,
+                                        job_argv_config=conf)
+
+        assert job.params == dict(seed=[1, 2, 3], n=5)
+
+    def it_collects_a_single_occurrence_into_a_list():
+
+        def target(seed):
+            return seed
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            repeated_params={'seed'})
+        argv = ['--id=1', '--rep=0', '--', 'seed=1']
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=dict(seed='int'),
+                                        job_argv_config=conf)
+
+        assert job.params == dict(seed=[1])
+
+    def it_still_rejects_other_duplicates():
+
+        def target(seed, n):
+            return seed, n
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            repeated_params={'seed'})
+        argv = ['--id=1', '--rep=0', '--', 'seed=1', 'n=1', 'n=2']
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target,
+                                      typemap=dict(seed='int', n='int'),
+                                      job_argv_config=conf)
+
+    def it_round_trips_through_argv_from_job():
+
+        def target(seed):
+            return seed
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            repeated_params={'seed'})
+        job = multijob.job.Job(4, 2, target, dict(seed=[3, 1, 3]))
+
+        argv = commandline.argv_from_job(job, job_argv_config=conf)
+        decoded = commandline.job_from_argv(argv, target,
+                                            typemap=dict(seed='int'),
+                                            job_argv_config=conf)
+
+        assert decoded.params == job.params
+
+def describe_job_from_argv_with_positional_param():
+
+    def it_collects_params_without_kv_separator():
+
+        def target(n, files):
+            return n, files
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            positional_param='files')
+        argv = ['--id=1', '--rep=0', '--', 'n=2', 'in.pcap', 'out.pcap']
+        typemap = dict(n='int', files='str')
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=typemap,
+                                        job_argv_config=conf)
+
+        assert job.params == dict(n=2, files=['in.pcap', 'out.pcap'])
+
+    def it_provides_an_empty_list_without_positionals():
+
+        def target(files):
+            return files
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            positional_param='files')
+        argv = ['--id=1', '--rep=0', '--']
+
+        job = commandline.job_from_argv(argv, target,
+                                        typemap=dict(files='str'),
+                                        job_argv_config=conf)
+
+        assert job.params == dict(files=[])
+
+    def it_rejects_the_positional_param_as_named_argument():
+
+        def target(files):
+            return files
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            positional_param='files')
+        argv = ['--id=1', '--rep=0', '--', 'files=a', 'b']
+
+        with pytest.raises(ValueError):
+            commandline.job_from_argv(argv, target,
+                                      typemap=dict(files='str'),
+                                      job_argv_config=conf)
+
+    def it_round_trips_through_argv_from_job():
+
+        def target(n, files):
+            return n, files
+
+        conf = commandline.JobArgvConfig(
+            job_id_key='--id',
+            repetition_id_key='--rep',
+            positional_param='files')
+        typemap = dict(n='int', files='str')
+        job = multijob.job.Job(4, 2, target,
+                               dict(n=1, files=['b.txt', 'a.txt']))
+
+        argv = commandline.argv_from_job(job, job_argv_config=conf)
+        decoded = commandline.job_from_argv(argv, target,
+                                            typemap=typemap,
+                                            job_argv_config=conf)
+
+        assert decoded.params == job.params